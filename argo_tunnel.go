@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -42,13 +44,16 @@ type ArgoTunnelDetailResponse struct {
 
 // ArgoTunnels lists all tunnels.
 //
+// Deprecated: use ListTunnels, which supports the newer Named Tunnel model
+// and filtering.
+//
 // API reference: https://api.cloudflare.com/#argo-tunnel-list-argo-tunnels
 func (api *API) ArgoTunnels(ctx context.Context, accountID string) ([]ArgoTunnel, error) {
-	uri := "/accounts/" + accountID + "/tunnels"
+	uri := tunnelsURI(accountID, nil)
 
 	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
 	if err != nil {
-		return []ArgoTunnel{}, errors.Wrap(err, errMakeRequestError)
+		return []ArgoTunnel{}, classifyTunnelError(err, Response{})
 	}
 
 	var argoDetailsResponse ArgoTunnelsDetailResponse
@@ -56,6 +61,9 @@ func (api *API) ArgoTunnels(ctx context.Context, accountID string) ([]ArgoTunnel
 	if err != nil {
 		return []ArgoTunnel{}, errors.Wrap(err, errUnmarshalError)
 	}
+	if err := classifyTunnelError(nil, argoDetailsResponse.Response); err != nil {
+		return []ArgoTunnel{}, err
+	}
 	return argoDetailsResponse.Result, nil
 }
 
@@ -67,7 +75,7 @@ func (api *API) ArgoTunnel(ctx context.Context, accountID, tunnelUUID string) (A
 
 	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
 	if err != nil {
-		return ArgoTunnel{}, errors.Wrap(err, errMakeRequestError)
+		return ArgoTunnel{}, classifyTunnelError(err, Response{})
 	}
 
 	var argoDetailsResponse ArgoTunnelDetailResponse
@@ -75,6 +83,9 @@ func (api *API) ArgoTunnel(ctx context.Context, accountID, tunnelUUID string) (A
 	if err != nil {
 		return ArgoTunnel{}, errors.Wrap(err, errUnmarshalError)
 	}
+	if err := classifyTunnelError(nil, argoDetailsResponse.Response); err != nil {
+		return ArgoTunnel{}, err
+	}
 	return argoDetailsResponse.Result, nil
 }
 
@@ -88,7 +99,7 @@ func (api *API) CreateArgoTunnel(ctx context.Context, accountID, name, secret st
 
 	res, err := api.makeRequestContext(ctx, "POST", uri, tunnel)
 	if err != nil {
-		return ArgoTunnel{}, errors.Wrap(err, errMakeRequestError)
+		return ArgoTunnel{}, classifyTunnelError(err, Response{})
 	}
 
 	var argoDetailsResponse ArgoTunnelDetailResponse
@@ -96,6 +107,9 @@ func (api *API) CreateArgoTunnel(ctx context.Context, accountID, name, secret st
 	if err != nil {
 		return ArgoTunnel{}, errors.Wrap(err, errUnmarshalError)
 	}
+	if err := classifyTunnelError(nil, argoDetailsResponse.Response); err != nil {
+		return ArgoTunnel{}, err
+	}
 	return argoDetailsResponse.Result, nil
 }
 
@@ -107,7 +121,7 @@ func (api *API) DeleteArgoTunnel(ctx context.Context, accountID, tunnelUUID stri
 
 	res, err := api.makeRequestContext(ctx, "DELETE", uri, nil)
 	if err != nil {
-		return errors.Wrap(err, errMakeRequestError)
+		return classifyTunnelError(err, Response{})
 	}
 
 	var argoDetailsResponse ArgoTunnelDetailResponse
@@ -116,18 +130,43 @@ func (api *API) DeleteArgoTunnel(ctx context.Context, accountID, tunnelUUID stri
 		return errors.Wrap(err, errUnmarshalError)
 	}
 
-	return nil
+	return classifyTunnelError(nil, argoDetailsResponse.Response)
 }
 
 // CleanupArgoTunnelConnections deletes any inactive connections on a tunnel.
 //
 // API reference: https://api.cloudflare.com/#argo-tunnel-clean-up-argo-tunnel-connections
 func (api *API) CleanupArgoTunnelConnections(ctx context.Context, accountID, tunnelUUID string) error {
+	return api.CleanupArgoTunnelConnectionsWithParams(ctx, accountID, tunnelUUID, nil)
+}
+
+// CleanupTunnelConnectionsParams encodes the query parameters accepted when
+// cleaning up inactive tunnel connections.
+type CleanupTunnelConnectionsParams struct {
+	// ClientID, when set, restricts the cleanup to connections opened by the
+	// connector with this ID, leaving sibling replicas' connections alone.
+	ClientID uuid.UUID
+}
+
+// ForClient scopes the cleanup to the connections opened by the given
+// connector.
+func ForClient(clientID uuid.UUID) *CleanupTunnelConnectionsParams {
+	return &CleanupTunnelConnectionsParams{ClientID: clientID}
+}
+
+// CleanupArgoTunnelConnectionsWithParams deletes any inactive connections on
+// a tunnel, optionally scoped by params. params may be nil.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-clean-up-argo-tunnel-connections
+func (api *API) CleanupArgoTunnelConnectionsWithParams(ctx context.Context, accountID, tunnelUUID string, params *CleanupTunnelConnectionsParams) error {
 	uri := fmt.Sprintf("/accounts/%s/tunnels/%s/connections", accountID, tunnelUUID)
+	if params != nil && params.ClientID != uuid.Nil {
+		uri += "?" + url.Values{"client_id": {params.ClientID.String()}}.Encode()
+	}
 
 	res, err := api.makeRequestContext(ctx, "DELETE", uri, nil)
 	if err != nil {
-		return errors.Wrap(err, errMakeRequestError)
+		return classifyTunnelError(err, Response{})
 	}
 
 	var argoDetailsResponse ArgoTunnelDetailResponse
@@ -136,5 +175,5 @@ func (api *API) CleanupArgoTunnelConnections(ctx context.Context, accountID, tun
 		return errors.Wrap(err, errUnmarshalError)
 	}
 
-	return nil
-}
\ No newline at end of file
+	return classifyTunnelError(nil, argoDetailsResponse.Response)
+}
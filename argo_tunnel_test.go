@@ -0,0 +1,14 @@
+package cloudflare
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForClientSetsClientID(t *testing.T) {
+	clientID := uuid.New()
+	params := ForClient(clientID)
+	assert.Equal(t, clientID, params.ClientID)
+}
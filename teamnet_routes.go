@@ -0,0 +1,242 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ErrMissingNetwork is returned when AddRoute is called with a NewRoute that
+// has no Network set.
+var ErrMissingNetwork = errors.New("network is required")
+
+// Route is a private network route, reachable through a Named Tunnel, that
+// has been added to an account's routing table.
+type Route struct {
+	Network          *net.IPNet `json:"network,omitempty"`
+	TunnelID         uuid.UUID  `json:"tunnel_id,omitempty"`
+	TunnelName       string     `json:"tunnel_name,omitempty"`
+	Comment          string     `json:"comment,omitempty"`
+	CreatedAt        time.Time  `json:"created_at,omitempty"`
+	DeletedAt        time.Time  `json:"deleted_at,omitempty"`
+	VirtualNetworkID *uuid.UUID `json:"virtual_network_id,omitempty"`
+}
+
+// routeJSON is the wire representation of a Route; Network is transmitted as
+// a CIDR string rather than net.IPNet's default JSON encoding.
+type routeJSON struct {
+	Network          string     `json:"network,omitempty"`
+	TunnelID         uuid.UUID  `json:"tunnel_id,omitempty"`
+	TunnelName       string     `json:"tunnel_name,omitempty"`
+	Comment          string     `json:"comment,omitempty"`
+	CreatedAt        time.Time  `json:"created_at,omitempty"`
+	DeletedAt        time.Time  `json:"deleted_at,omitempty"`
+	VirtualNetworkID *uuid.UUID `json:"virtual_network_id,omitempty"`
+}
+
+// MarshalJSON encodes Network as a CIDR string.
+func (r Route) MarshalJSON() ([]byte, error) {
+	route := routeJSON{
+		TunnelID:         r.TunnelID,
+		TunnelName:       r.TunnelName,
+		Comment:          r.Comment,
+		CreatedAt:        r.CreatedAt,
+		DeletedAt:        r.DeletedAt,
+		VirtualNetworkID: r.VirtualNetworkID,
+	}
+	if r.Network != nil {
+		route.Network = r.Network.String()
+	}
+	return json.Marshal(route)
+}
+
+// UnmarshalJSON decodes Network from a CIDR string.
+func (r *Route) UnmarshalJSON(data []byte) error {
+	var route routeJSON
+	if err := json.Unmarshal(data, &route); err != nil {
+		return err
+	}
+
+	r.TunnelID = route.TunnelID
+	r.TunnelName = route.TunnelName
+	r.Comment = route.Comment
+	r.CreatedAt = route.CreatedAt
+	r.DeletedAt = route.DeletedAt
+	r.VirtualNetworkID = route.VirtualNetworkID
+
+	if route.Network != "" {
+		_, network, err := net.ParseCIDR(route.Network)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse route network")
+		}
+		r.Network = network
+	}
+	return nil
+}
+
+// NewRoute describes a new Route to be added to the account's routing
+// table.
+type NewRoute struct {
+	Network  *net.IPNet
+	TunnelID uuid.UUID
+	Comment  string
+	VNetID   *uuid.UUID
+}
+
+// RouteFilter encodes the query parameters accepted when listing Routes.
+type RouteFilter struct {
+	v url.Values
+}
+
+// NewRouteFilter initializes a new RouteFilter.
+func NewRouteFilter() *RouteFilter {
+	return &RouteFilter{v: url.Values{}}
+}
+
+// WithTunnelID filters routes bound to the given tunnel.
+func (rf *RouteFilter) WithTunnelID(tunnelID uuid.UUID) {
+	rf.v.Set("tunnel_id", tunnelID.String())
+}
+
+// WithNetwork filters routes whose network is a subset or superset of the
+// given CIDR, depending on the Cloudflare API's interpretation.
+func (rf *RouteFilter) WithNetwork(network string) {
+	rf.v.Set("network", network)
+}
+
+// WithExistedAt filters routes that existed at the given point in time.
+func (rf *RouteFilter) WithExistedAt(existedAt time.Time) {
+	rf.v.Set("existed_at", existedAt.Format(time.RFC3339))
+}
+
+// WithVirtualNetworkID filters routes that belong to the given virtual
+// network.
+func (rf *RouteFilter) WithVirtualNetworkID(vnetID uuid.UUID) {
+	rf.v.Set("virtual_network_id", vnetID.String())
+}
+
+// Encode turns the filter into a URL-encoded query string.
+func (rf *RouteFilter) Encode() string {
+	return rf.v.Encode()
+}
+
+// routesDetailResponse is used for representing the API response payload for
+// multiple Routes.
+type routesDetailResponse struct {
+	Result []Route `json:"result"`
+	Response
+}
+
+// routeDetailResponse is used for representing the API response payload for
+// a single Route.
+type routeDetailResponse struct {
+	Result Route `json:"result"`
+	Response
+}
+
+// ListRoutes lists the routes in an account's private-network routing
+// table, optionally filtered by filter.
+//
+// API reference: https://api.cloudflare.com/#tunnel-route-list-tunnel-routes
+func (api *API) ListRoutes(ctx context.Context, accountID string, filter *RouteFilter) ([]Route, error) {
+	uri := "/accounts/" + accountID + "/teamnet/routes"
+	if filter != nil {
+		if query := filter.Encode(); query != "" {
+			uri += "?" + query
+		}
+	}
+
+	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return []Route{}, classifyTunnelError(err, Response{})
+	}
+
+	var routesResponse routesDetailResponse
+	err = json.Unmarshal(res, &routesResponse)
+	if err != nil {
+		return []Route{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, routesResponse.Response); err != nil {
+		return []Route{}, err
+	}
+	return routesResponse.Result, nil
+}
+
+// AddRoute adds a new route to an account's private-network routing table.
+//
+// API reference: https://api.cloudflare.com/#tunnel-route-create-tunnel-route
+func (api *API) AddRoute(ctx context.Context, accountID string, newRoute NewRoute) (Route, error) {
+	if newRoute.Network == nil {
+		return Route{}, ErrMissingNetwork
+	}
+	uri := "/accounts/" + accountID + "/teamnet/routes/network/" + url.QueryEscape(newRoute.Network.String())
+
+	params := struct {
+		TunnelID uuid.UUID  `json:"tunnel_id"`
+		Comment  string     `json:"comment,omitempty"`
+		VNetID   *uuid.UUID `json:"virtual_network_id,omitempty"`
+	}{TunnelID: newRoute.TunnelID, Comment: newRoute.Comment, VNetID: newRoute.VNetID}
+
+	res, err := api.makeRequestContext(ctx, "POST", uri, params)
+	if err != nil {
+		return Route{}, classifyTunnelError(err, Response{})
+	}
+
+	var routeResponse routeDetailResponse
+	err = json.Unmarshal(res, &routeResponse)
+	if err != nil {
+		return Route{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, routeResponse.Response); err != nil {
+		return Route{}, err
+	}
+	return routeResponse.Result, nil
+}
+
+// DeleteRoute removes a route from an account's private-network routing
+// table.
+//
+// API reference: https://api.cloudflare.com/#tunnel-route-delete-tunnel-route
+func (api *API) DeleteRoute(ctx context.Context, accountID string, routeID uuid.UUID) error {
+	uri := fmt.Sprintf("/accounts/%s/teamnet/routes/%s", accountID, routeID)
+
+	res, err := api.makeRequestContext(ctx, "DELETE", uri, nil)
+	if err != nil {
+		return classifyTunnelError(err, Response{})
+	}
+
+	var routeResponse routeDetailResponse
+	err = json.Unmarshal(res, &routeResponse)
+	if err != nil {
+		return errors.Wrap(err, errUnmarshalError)
+	}
+	return classifyTunnelError(nil, routeResponse.Response)
+}
+
+// GetByIP finds the route that an IP address falls under.
+//
+// API reference: https://api.cloudflare.com/#tunnel-route-get-tunnel-route-by-ip
+func (api *API) GetByIP(ctx context.Context, accountID string, ip net.IP) (Route, error) {
+	uri := fmt.Sprintf("/accounts/%s/teamnet/routes/ip/%s", accountID, ip.String())
+
+	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return Route{}, classifyTunnelError(err, Response{})
+	}
+
+	var routeResponse routeDetailResponse
+	err = json.Unmarshal(res, &routeResponse)
+	if err != nil {
+		return Route{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, routeResponse.Response); err != nil {
+		return Route{}, err
+	}
+	return routeResponse.Result, nil
+}
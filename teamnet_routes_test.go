@@ -0,0 +1,49 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRouteRequiresNetwork(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.AddRoute(context.Background(), testAccountID, NewRoute{TunnelID: uuid.New()})
+	assert.True(t, errors.Is(err, ErrMissingNetwork))
+}
+
+func TestRouteMarshalUnmarshalRoundTrip(t *testing.T) {
+	_, network, err := net.ParseCIDR("192.0.2.0/24")
+	assert.NoError(t, err)
+
+	want := Route{
+		Network:    network,
+		TunnelID:   uuid.New(),
+		TunnelName: "my-tunnel",
+		Comment:    "office network",
+	}
+
+	data, err := json.Marshal(want)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"network":"192.0.2.0/24"`)
+
+	var got Route
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, want.TunnelID, got.TunnelID)
+	assert.Equal(t, want.TunnelName, got.TunnelName)
+	assert.Equal(t, want.Comment, got.Comment)
+	assert.Equal(t, want.Network.String(), got.Network.String())
+}
+
+func TestRouteUnmarshalNilNetwork(t *testing.T) {
+	var got Route
+	assert.NoError(t, json.Unmarshal([]byte(`{"tunnel_id":"`+uuid.New().String()+`"}`), &got))
+	assert.Nil(t, got.Network)
+}
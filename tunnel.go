@@ -0,0 +1,353 @@
+package cloudflare
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ErrTunnelNameConflict is raised when creating a named tunnel with a name
+// that is already in use on the account.
+var ErrTunnelNameConflict = errors.New("tunnel with name already exists")
+
+// errCodeTunnelNameConflict is the Cloudflare API error code returned when a
+// Named Tunnel create request collides with an existing tunnel's name.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-create-argo-tunnel
+const errCodeTunnelNameConflict = 1003
+
+// tunnelNameConflict reports whether errs contains the API's name-conflict
+// error code.
+func tunnelNameConflict(errs []ResponseInfo) bool {
+	for _, apiErr := range errs {
+		if apiErr.Code == errCodeTunnelNameConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// Tunnel is the struct definition of a Named Tunnel.
+type Tunnel struct {
+	ID          uuid.UUID    `json:"id,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	CreatedAt   time.Time    `json:"created_at,omitempty"`
+	DeletedAt   time.Time    `json:"deleted_at,omitempty"`
+	Connections []Connection `json:"connections,omitempty"`
+}
+
+// Connection is the struct definition of a connector registered for a
+// Named Tunnel.
+type Connection struct {
+	ID       uuid.UUID `json:"id,omitempty"`
+	OriginIP net.IP    `json:"origin_ip,omitempty"`
+	OpenedAt time.Time `json:"opened_at,omitempty"`
+}
+
+// ActiveClient is a connector process that currently has (or recently had)
+// connections attached to a Named Tunnel.
+type ActiveClient struct {
+	ID          uuid.UUID    `json:"id,omitempty"`
+	Features    []string     `json:"features,omitempty"`
+	Version     string       `json:"version,omitempty"`
+	Arch        string       `json:"arch,omitempty"`
+	RunAt       time.Time    `json:"run_at,omitempty"`
+	Connections []Connection `json:"connections,omitempty"`
+}
+
+// TunnelWithToken is the result of creating a Named Tunnel: the tunnel
+// itself plus the token the connector uses to register with Cloudflare.
+type TunnelWithToken struct {
+	Tunnel
+	Token string `json:"token"`
+}
+
+// TunnelsDetailResponse is used for representing the API response payload
+// for multiple Named Tunnels.
+type TunnelsDetailResponse struct {
+	Result []Tunnel `json:"result"`
+	Response
+}
+
+// TunnelDetailResponse is used for representing the API response payload
+// for a single Named Tunnel.
+type TunnelDetailResponse struct {
+	Result Tunnel `json:"result"`
+	Response
+}
+
+// tunnelWithTokenDetailResponse is used for representing the API response
+// payload for a newly-created Named Tunnel.
+type tunnelWithTokenDetailResponse struct {
+	Result TunnelWithToken `json:"result"`
+	Response
+}
+
+// tunnelTokenResponse is used for representing the API response payload for
+// a Named Tunnel's connector-registration token.
+type tunnelTokenResponse struct {
+	Result string `json:"result"`
+	Response
+}
+
+// activeClientsDetailResponse is used for representing the API response
+// payload for the connectors currently attached to a Named Tunnel.
+type activeClientsDetailResponse struct {
+	Result []ActiveClient `json:"result"`
+	Response
+}
+
+// TunnelFilter encodes the query parameters accepted when listing Named
+// Tunnels. The zero value matches every tunnel.
+type TunnelFilter struct {
+	Name          string
+	UUID          uuid.UUID
+	ExistedAt     time.Time
+	IsDeleted     *bool
+	ExcludePrefix string
+	IncludePrefix string
+	Page          int
+	PerPage       int
+}
+
+// Encode turns the filter into a URL-encoded query string.
+func (tf *TunnelFilter) Encode() string {
+	v := url.Values{}
+	if tf.Name != "" {
+		v.Set("name", tf.Name)
+	}
+	if tf.UUID != uuid.Nil {
+		v.Set("uuid", tf.UUID.String())
+	}
+	if !tf.ExistedAt.IsZero() {
+		v.Set("existed_at", tf.ExistedAt.Format(time.RFC3339))
+	}
+	if tf.IsDeleted != nil {
+		v.Set("is_deleted", fmt.Sprintf("%t", *tf.IsDeleted))
+	}
+	if tf.ExcludePrefix != "" {
+		v.Set("exclude_prefix", tf.ExcludePrefix)
+	}
+	if tf.IncludePrefix != "" {
+		v.Set("include_prefix", tf.IncludePrefix)
+	}
+	if tf.Page != 0 {
+		v.Set("page", fmt.Sprintf("%d", tf.Page))
+	}
+	if tf.PerPage != 0 {
+		v.Set("per_page", fmt.Sprintf("%d", tf.PerPage))
+	}
+	return v.Encode()
+}
+
+// ListTunnelConnectionsFilter encodes the query parameters accepted when
+// listing the connectors attached to a Named Tunnel.
+type ListTunnelConnectionsFilter struct {
+	// ShowRecentlyDisconnected, when true, includes connections that have
+	// recently disconnected in addition to the active ones.
+	ShowRecentlyDisconnected bool
+}
+
+// Encode turns the filter into a URL-encoded query string.
+func (f *ListTunnelConnectionsFilter) Encode() string {
+	v := url.Values{}
+	if f.ShowRecentlyDisconnected {
+		v.Set("show_recently_disconnected", "true")
+	}
+	return v.Encode()
+}
+
+// tunnelsURI builds the list-tunnels URI, optionally filtered. It is shared
+// by ListTunnels and the legacy ArgoTunnels so both hit the exact same
+// endpoint and query parameters.
+func tunnelsURI(accountID string, filter *TunnelFilter) string {
+	uri := "/accounts/" + accountID + "/tunnels"
+	if filter != nil {
+		if query := filter.Encode(); query != "" {
+			uri += "?" + query
+		}
+	}
+	return uri
+}
+
+// ListTunnels lists Named Tunnels, optionally filtered by filter.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-list-argo-tunnels
+func (api *API) ListTunnels(ctx context.Context, accountID string, filter *TunnelFilter) ([]Tunnel, error) {
+	uri := tunnelsURI(accountID, filter)
+
+	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return []Tunnel{}, classifyTunnelError(err, Response{})
+	}
+
+	var tunnelsDetailResponse TunnelsDetailResponse
+	err = json.Unmarshal(res, &tunnelsDetailResponse)
+	if err != nil {
+		return []Tunnel{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, tunnelsDetailResponse.Response); err != nil {
+		return []Tunnel{}, err
+	}
+	return tunnelsDetailResponse.Result, nil
+}
+
+// NamedTunnels lists all Named Tunnels.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-list-argo-tunnels
+func (api *API) NamedTunnels(ctx context.Context, accountID string) ([]Tunnel, error) {
+	return api.ListTunnels(ctx, accountID, nil)
+}
+
+// NamedTunnel returns a single Named Tunnel.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-get-argo-tunnel
+func (api *API) NamedTunnel(ctx context.Context, accountID string, tunnelID uuid.UUID) (Tunnel, error) {
+	uri := fmt.Sprintf("/accounts/%s/tunnels/%s", accountID, tunnelID)
+
+	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return Tunnel{}, classifyTunnelError(err, Response{})
+	}
+
+	var tunnelDetailResponse TunnelDetailResponse
+	err = json.Unmarshal(res, &tunnelDetailResponse)
+	if err != nil {
+		return Tunnel{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, tunnelDetailResponse.Response); err != nil {
+		return Tunnel{}, err
+	}
+	return tunnelDetailResponse.Result, nil
+}
+
+// newTunnelSecret generates the 32-byte secret a Named Tunnel uses to
+// authenticate its connectors.
+func newTunnelSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Wrap(err, "failed to generate tunnel secret")
+	}
+	return secret, nil
+}
+
+// CreateNamedTunnel creates a new Named Tunnel for the account. If secret is
+// nil, a random 32-byte secret is generated. The returned TunnelWithToken
+// embeds the base64 token used by cloudflared to register as a connector.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-create-argo-tunnel
+func (api *API) CreateNamedTunnel(ctx context.Context, accountID, name string, secret []byte) (TunnelWithToken, error) {
+	if secret == nil {
+		var err error
+		secret, err = newTunnelSecret()
+		if err != nil {
+			return TunnelWithToken{}, err
+		}
+	}
+
+	uri := "/accounts/" + accountID + "/tunnels"
+
+	params := struct {
+		Name   string `json:"name"`
+		Secret []byte `json:"tunnel_secret"`
+	}{Name: name, Secret: secret}
+
+	res, err := api.makeRequestContext(ctx, "POST", uri, params)
+	if err != nil {
+		if apiErr, ok := asAPIRequestError(err); ok && tunnelNameConflict(apiErr.Errors) {
+			return TunnelWithToken{}, ErrTunnelNameConflict
+		}
+		return TunnelWithToken{}, classifyTunnelError(err, Response{})
+	}
+
+	var detailResponse tunnelWithTokenDetailResponse
+	err = json.Unmarshal(res, &detailResponse)
+	if err != nil {
+		return TunnelWithToken{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if tunnelNameConflict(detailResponse.Errors) {
+		return TunnelWithToken{}, ErrTunnelNameConflict
+	}
+	if err := classifyTunnelError(nil, detailResponse.Response); err != nil {
+		return TunnelWithToken{}, err
+	}
+	return detailResponse.Result, nil
+}
+
+// DeleteNamedTunnel removes a single Named Tunnel.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-delete-argo-tunnel
+func (api *API) DeleteNamedTunnel(ctx context.Context, accountID string, tunnelID uuid.UUID) error {
+	uri := fmt.Sprintf("/accounts/%s/tunnels/%s", accountID, tunnelID)
+
+	res, err := api.makeRequestContext(ctx, "DELETE", uri, nil)
+	if err != nil {
+		return classifyTunnelError(err, Response{})
+	}
+
+	var tunnelDetailResponse TunnelDetailResponse
+	err = json.Unmarshal(res, &tunnelDetailResponse)
+	if err != nil {
+		return errors.Wrap(err, errUnmarshalError)
+	}
+
+	return classifyTunnelError(nil, tunnelDetailResponse.Response)
+}
+
+// TunnelToken returns the base64 token a connector uses to register with a
+// Named Tunnel.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-get-argo-tunnel-token
+func (api *API) TunnelToken(ctx context.Context, accountID string, tunnelID uuid.UUID) (string, error) {
+	uri := fmt.Sprintf("/accounts/%s/tunnels/%s/token", accountID, tunnelID)
+
+	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return "", classifyTunnelError(err, Response{})
+	}
+
+	var tokenResponse tunnelTokenResponse
+	err = json.Unmarshal(res, &tokenResponse)
+	if err != nil {
+		return "", errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, tokenResponse.Response); err != nil {
+		return "", err
+	}
+	return tokenResponse.Result, nil
+}
+
+// TunnelConnections returns the connectors (and their connections) currently
+// attached to a Named Tunnel. filter may be nil.
+//
+// API reference: https://api.cloudflare.com/#argo-tunnel-list-argo-tunnel-connections
+func (api *API) TunnelConnections(ctx context.Context, accountID string, tunnelID uuid.UUID, filter *ListTunnelConnectionsFilter) ([]ActiveClient, error) {
+	uri := fmt.Sprintf("/accounts/%s/tunnels/%s/connections", accountID, tunnelID)
+	if filter != nil {
+		if query := filter.Encode(); query != "" {
+			uri += "?" + query
+		}
+	}
+
+	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return []ActiveClient{}, classifyTunnelError(err, Response{})
+	}
+
+	var activeClientsResponse activeClientsDetailResponse
+	err = json.Unmarshal(res, &activeClientsResponse)
+	if err != nil {
+		return []ActiveClient{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, activeClientsResponse.Response); err != nil {
+		return []ActiveClient{}, err
+	}
+	return activeClientsResponse.Result, nil
+}
@@ -0,0 +1,80 @@
+package cloudflare
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors returned by the Named Tunnel, Teamnet Route, and Virtual
+// Network endpoints so that callers can use errors.Is instead of matching
+// on error strings.
+var (
+	// ErrUnauthorized is returned when the API credentials do not have
+	// access to the requested resource.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrBadRequest is returned when the request itself was malformed.
+	ErrBadRequest = errors.New("bad request")
+
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrAPINoSuccess is returned when the API reports success: false
+	// without a more specific HTTP status to classify.
+	ErrAPINoSuccess = errors.New("api response was not successful")
+)
+
+// asAPIRequestError unwraps err looking for an APIRequestError, checking err
+// itself (makeRequestContext returns one on a non-2xx response) and its
+// pkg/errors cause (in case something further up the call chain wrapped it).
+// makeRequestContext returns APIRequestError by value, but both forms are
+// checked in case that ever changes.
+func asAPIRequestError(err error) (*APIRequestError, bool) {
+	if apiErr, ok := err.(APIRequestError); ok {
+		return &apiErr, true
+	}
+	if apiErr, ok := err.(*APIRequestError); ok {
+		return apiErr, true
+	}
+	cause := errors.Cause(err)
+	if apiErr, ok := cause.(APIRequestError); ok {
+		return &apiErr, true
+	}
+	if apiErr, ok := cause.(*APIRequestError); ok {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// classifyTunnelError turns a failed request, or a successfully-decoded but
+// unsuccessful response, into one of the sentinel errors above while
+// preserving the underlying Cloudflare error code and message. Exactly one
+// of requestErr and response is expected to carry the failure.
+func classifyTunnelError(requestErr error, response Response) error {
+	if requestErr != nil {
+		if apiErr, ok := asAPIRequestError(requestErr); ok {
+			switch apiErr.StatusCode {
+			case http.StatusUnauthorized:
+				return fmt.Errorf("%w: %s", ErrUnauthorized, apiErr.Error())
+			case http.StatusBadRequest:
+				return fmt.Errorf("%w: %s", ErrBadRequest, apiErr.Error())
+			case http.StatusNotFound:
+				return fmt.Errorf("%w: %s", ErrNotFound, apiErr.Error())
+			default:
+				return fmt.Errorf("%w: %s", ErrAPINoSuccess, apiErr.Error())
+			}
+		}
+		return errors.Wrap(requestErr, errMakeRequestError)
+	}
+
+	if !response.Success {
+		if len(response.Errors) > 0 {
+			return fmt.Errorf("%w: %s (code %d)", ErrAPINoSuccess, response.Errors[0].Message, response.Errors[0].Code)
+		}
+		return ErrAPINoSuccess
+	}
+
+	return nil
+}
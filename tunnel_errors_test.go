@@ -0,0 +1,109 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyTunnelErrorMapsStatusCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    error
+	}{
+		{"unauthorized", http.StatusUnauthorized, ErrUnauthorized},
+		{"bad request", http.StatusBadRequest, ErrBadRequest},
+		{"not found", http.StatusNotFound, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestErr := APIRequestError{StatusCode: tt.statusCode}
+
+			err := classifyTunnelError(requestErr, Response{})
+
+			assert.True(t, errors.Is(err, tt.wantErr), "expected error chain to contain %v, got %v", tt.wantErr, err)
+		})
+	}
+}
+
+func TestClassifyTunnelErrorUnclassifiedStatusMapsToAPINoSuccess(t *testing.T) {
+	requestErr := APIRequestError{StatusCode: http.StatusInternalServerError}
+
+	err := classifyTunnelError(requestErr, Response{})
+
+	assert.False(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(err, ErrAPINoSuccess))
+}
+
+func TestClassifyTunnelErrorNonAPIErrorFallsBackToMakeRequestError(t *testing.T) {
+	requestErr := errors.New("connection reset by peer")
+
+	err := classifyTunnelError(requestErr, Response{})
+
+	assert.False(t, errors.Is(err, ErrAPINoSuccess))
+	assert.Contains(t, err.Error(), errMakeRequestError)
+}
+
+func TestClassifyTunnelErrorUnsuccessfulResponse(t *testing.T) {
+	response := Response{
+		Success: false,
+		Errors:  []ResponseInfo{{Code: 9999, Message: "something broke"}},
+	}
+
+	err := classifyTunnelError(nil, response)
+
+	assert.True(t, errors.Is(err, ErrAPINoSuccess))
+	assert.Contains(t, err.Error(), "something broke")
+}
+
+func TestTunnelNameConflict(t *testing.T) {
+	assert.True(t, tunnelNameConflict([]ResponseInfo{{Code: errCodeTunnelNameConflict, Message: "tunnel with name already exists"}}))
+	assert.False(t, tunnelNameConflict([]ResponseInfo{{Code: 1234, Message: "tunnel with name already exists"}}))
+	assert.False(t, tunnelNameConflict(nil))
+}
+
+func TestAsAPIRequestError(t *testing.T) {
+	apiErr := APIRequestError{StatusCode: http.StatusConflict}
+
+	got, ok := asAPIRequestError(apiErr)
+	assert.True(t, ok)
+	assert.Equal(t, apiErr, *got)
+
+	got, ok = asAPIRequestError(&apiErr)
+	assert.True(t, ok)
+	assert.Equal(t, apiErr, *got)
+
+	got, ok = asAPIRequestError(pkgerrors.Wrap(apiErr, "wrapped"))
+	assert.True(t, ok)
+	assert.Equal(t, apiErr, *got)
+
+	_, ok = asAPIRequestError(errors.New("unrelated"))
+	assert.False(t, ok)
+}
+
+// TestNamedTunnelNotFoundMapsToErrNotFound drives a real non-2xx response
+// through the HTTP client, the same path makeRequestContext uses in
+// production, to prove errors.Is(err, ErrNotFound) actually fires for a 404
+// and not just for a hand-constructed APIRequestError.
+func TestNamedTunnelNotFoundMapsToErrNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tunnelID := uuid.New()
+	mux.HandleFunc(fmt.Sprintf("/accounts/%s/tunnels/%s", testAccountID, tunnelID), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success":false,"errors":[{"code":1000,"message":"tunnel not found"}],"result":null}`)
+	})
+
+	_, err := client.NamedTunnel(context.Background(), testAccountID, tunnelID)
+	assert.True(t, errors.Is(err, ErrNotFound), "expected error chain to contain ErrNotFound, got %v", err)
+}
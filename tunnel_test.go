@@ -0,0 +1,62 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTunnelFilterEncode(t *testing.T) {
+	assert.Equal(t, "", (&TunnelFilter{}).Encode())
+
+	id := uuid.New()
+	existedAt := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	isDeleted := true
+	filter := &TunnelFilter{
+		Name:          "my-tunnel",
+		UUID:          id,
+		ExistedAt:     existedAt,
+		IsDeleted:     &isDeleted,
+		ExcludePrefix: "test-",
+		IncludePrefix: "prod-",
+		Page:          2,
+		PerPage:       25,
+	}
+
+	values, err := url.ParseQuery(filter.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, "my-tunnel", values.Get("name"))
+	assert.Equal(t, id.String(), values.Get("uuid"))
+	assert.Equal(t, existedAt.Format(time.RFC3339), values.Get("existed_at"))
+	assert.Equal(t, "true", values.Get("is_deleted"))
+	assert.Equal(t, "test-", values.Get("exclude_prefix"))
+	assert.Equal(t, "prod-", values.Get("include_prefix"))
+	assert.Equal(t, "2", values.Get("page"))
+	assert.Equal(t, "25", values.Get("per_page"))
+}
+
+// TestCreateNamedTunnelNameConflictEndToEnd drives a real HTTP 409 carrying
+// the name-conflict error code through CreateNamedTunnel and proves it
+// surfaces as ErrTunnelNameConflict rather than the generic
+// errMakeRequestError wrap, matching the collision-retry behavior the
+// request asked for.
+func TestCreateNamedTunnelNameConflictEndToEnd(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("/accounts/%s/tunnels", testAccountID), func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"success":false,"errors":[{"code":1003,"message":"tunnel with name already exists"}],"result":null}`)
+	})
+
+	_, err := client.CreateNamedTunnel(context.Background(), testAccountID, "my-tunnel", []byte("s3cr3t"))
+	assert.True(t, errors.Is(err, ErrTunnelNameConflict), "expected error chain to contain ErrTunnelNameConflict, got %v", err)
+}
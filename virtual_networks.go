@@ -0,0 +1,182 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// VirtualNetwork is an isolated private-IP routing context within an
+// account, letting overlapping CIDR ranges be routed through distinct
+// tunnels.
+type VirtualNetwork struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	IsDefault bool      `json:"is_default,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	DeletedAt time.Time `json:"deleted_at,omitempty"`
+}
+
+// NewVirtualNetwork describes a new VirtualNetwork to be created in the
+// account.
+type NewVirtualNetwork struct {
+	Name      string `json:"name"`
+	Comment   string `json:"comment,omitempty"`
+	IsDefault bool   `json:"is_default,omitempty"`
+}
+
+// UpdateVirtualNetwork describes the mutable fields of a VirtualNetwork.
+type UpdateVirtualNetwork struct {
+	Name      string `json:"name,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	IsDefault *bool  `json:"is_default,omitempty"`
+}
+
+// VnetFilter encodes the query parameters accepted when listing Virtual
+// Networks.
+type VnetFilter struct {
+	v url.Values
+}
+
+// NewVnetFilter initializes a new VnetFilter.
+func NewVnetFilter() *VnetFilter {
+	return &VnetFilter{v: url.Values{}}
+}
+
+// WithName filters virtual networks by exact name.
+func (vf *VnetFilter) WithName(name string) {
+	vf.v.Set("name", name)
+}
+
+// WithID filters virtual networks by ID.
+func (vf *VnetFilter) WithID(id uuid.UUID) {
+	vf.v.Set("id", id.String())
+}
+
+// WithIsDefault filters virtual networks by whether they are the account's
+// default.
+func (vf *VnetFilter) WithIsDefault(isDefault bool) {
+	vf.v.Set("is_default", fmt.Sprintf("%t", isDefault))
+}
+
+// WithExistedAt filters virtual networks that existed at the given point in
+// time.
+func (vf *VnetFilter) WithExistedAt(existedAt time.Time) {
+	vf.v.Set("existed_at", existedAt.Format(time.RFC3339))
+}
+
+// Encode turns the filter into a URL-encoded query string.
+func (vf *VnetFilter) Encode() string {
+	return vf.v.Encode()
+}
+
+// virtualNetworksDetailResponse is used for representing the API response
+// payload for multiple Virtual Networks.
+type virtualNetworksDetailResponse struct {
+	Result []VirtualNetwork `json:"result"`
+	Response
+}
+
+// virtualNetworkDetailResponse is used for representing the API response
+// payload for a single Virtual Network.
+type virtualNetworkDetailResponse struct {
+	Result VirtualNetwork `json:"result"`
+	Response
+}
+
+// CreateVirtualNetwork creates a new Virtual Network for the account.
+//
+// API reference: https://api.cloudflare.com/#virtual-networks-create-a-virtual-network
+func (api *API) CreateVirtualNetwork(ctx context.Context, accountID string, newVnet NewVirtualNetwork) (VirtualNetwork, error) {
+	uri := "/accounts/" + accountID + "/teamnet/virtual_networks"
+
+	res, err := api.makeRequestContext(ctx, "POST", uri, newVnet)
+	if err != nil {
+		return VirtualNetwork{}, classifyTunnelError(err, Response{})
+	}
+
+	var vnetResponse virtualNetworkDetailResponse
+	err = json.Unmarshal(res, &vnetResponse)
+	if err != nil {
+		return VirtualNetwork{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, vnetResponse.Response); err != nil {
+		return VirtualNetwork{}, err
+	}
+	return vnetResponse.Result, nil
+}
+
+// ListVirtualNetworks lists the Virtual Networks in an account, optionally
+// filtered by filter.
+//
+// API reference: https://api.cloudflare.com/#virtual-networks-list-virtual-networks
+func (api *API) ListVirtualNetworks(ctx context.Context, accountID string, filter *VnetFilter) ([]VirtualNetwork, error) {
+	uri := "/accounts/" + accountID + "/teamnet/virtual_networks"
+	if filter != nil {
+		if query := filter.Encode(); query != "" {
+			uri += "?" + query
+		}
+	}
+
+	res, err := api.makeRequestContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return []VirtualNetwork{}, classifyTunnelError(err, Response{})
+	}
+
+	var vnetsResponse virtualNetworksDetailResponse
+	err = json.Unmarshal(res, &vnetsResponse)
+	if err != nil {
+		return []VirtualNetwork{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, vnetsResponse.Response); err != nil {
+		return []VirtualNetwork{}, err
+	}
+	return vnetsResponse.Result, nil
+}
+
+// DeleteVirtualNetwork deletes a Virtual Network from the account.
+//
+// API reference: https://api.cloudflare.com/#virtual-networks-delete-a-virtual-network
+func (api *API) DeleteVirtualNetwork(ctx context.Context, accountID string, id uuid.UUID) error {
+	uri := fmt.Sprintf("/accounts/%s/teamnet/virtual_networks/%s", accountID, id)
+
+	res, err := api.makeRequestContext(ctx, "DELETE", uri, nil)
+	if err != nil {
+		return classifyTunnelError(err, Response{})
+	}
+
+	var vnetResponse virtualNetworkDetailResponse
+	err = json.Unmarshal(res, &vnetResponse)
+	if err != nil {
+		return errors.Wrap(err, errUnmarshalError)
+	}
+	return classifyTunnelError(nil, vnetResponse.Response)
+}
+
+// UpdateVirtualNetwork updates the mutable fields of a Virtual Network.
+//
+// API reference: https://api.cloudflare.com/#virtual-networks-update-a-virtual-network
+func (api *API) UpdateVirtualNetwork(ctx context.Context, accountID string, id uuid.UUID, update UpdateVirtualNetwork) (VirtualNetwork, error) {
+	uri := fmt.Sprintf("/accounts/%s/teamnet/virtual_networks/%s", accountID, id)
+
+	res, err := api.makeRequestContext(ctx, "PATCH", uri, update)
+	if err != nil {
+		return VirtualNetwork{}, classifyTunnelError(err, Response{})
+	}
+
+	var vnetResponse virtualNetworkDetailResponse
+	err = json.Unmarshal(res, &vnetResponse)
+	if err != nil {
+		return VirtualNetwork{}, errors.Wrap(err, errUnmarshalError)
+	}
+	if err := classifyTunnelError(nil, vnetResponse.Response); err != nil {
+		return VirtualNetwork{}, err
+	}
+	return vnetResponse.Result, nil
+}
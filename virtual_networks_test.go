@@ -0,0 +1,35 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVnetFilterEncode(t *testing.T) {
+	filter := NewVnetFilter()
+	assert.Equal(t, "", filter.Encode())
+
+	filter.WithName("my-vnet")
+	id := uuid.New()
+	filter.WithID(id)
+	filter.WithIsDefault(true)
+	existedAt := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	filter.WithExistedAt(existedAt)
+
+	assert.Equal(t,
+		"existed_at=2021-01-02T03%3A04%3A05Z&id="+id.String()+"&is_default=true&name=my-vnet",
+		filter.Encode(),
+	)
+}
+
+func TestUpdateVirtualNetworkOmitsUnsetFields(t *testing.T) {
+	update := UpdateVirtualNetwork{Comment: "updated comment"}
+
+	data, err := json.Marshal(update)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"comment":"updated comment"}`, string(data))
+}